@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Store is the in-memory view of the local issue database that automation
+// rules operate on. Backend is populated on a best-effort basis from the
+// configured backend's credentials; it is nil when none are available, in
+// which case rules only touch the local database.
+type Store struct {
+	Issues  []Issue
+	Backend Backend
+	DryRun  bool
+}
+
+// rule is a single automation task applied by `run-bots`. It mirrors
+// gopherbot's task list: each rule is registered in a slice and can be
+// toggled independently via .issueify/rules.yaml.
+type rule struct {
+	name string
+	fn   func(ctx context.Context, store *Store) error
+}
+
+// labelRuleYAML is the YAML shape of one auto_label pattern in rules.yaml.
+type labelRuleYAML struct {
+	Pattern string `yaml:"pattern"`
+	Label   string `yaml:"label"`
+}
+
+// rulesConfig is the shape of .issueify/rules.yaml, the configuration for
+// `run-bots`. Each rule under Rules is disabled unless explicitly enabled.
+type rulesConfig struct {
+	DryRun bool `yaml:"dry_run"`
+	Rules  struct {
+		FreezeStale struct {
+			Enabled  bool `yaml:"enabled"`
+			IdleDays int  `yaml:"idle_days"`
+		} `yaml:"freeze_stale"`
+		AutoLabel struct {
+			Enabled  bool            `yaml:"enabled"`
+			Patterns []labelRuleYAML `yaml:"patterns"`
+		} `yaml:"auto_label"`
+		AutoClose struct {
+			Enabled  bool `yaml:"enabled"`
+			IdleDays int  `yaml:"idle_days"`
+		} `yaml:"auto_close"`
+		AutoMilestone struct {
+			Enabled bool              `yaml:"enabled"`
+			ByLabel map[string]string `yaml:"by_label"`
+		} `yaml:"auto_milestone"`
+	} `yaml:"rules"`
+}
+
+// loadRulesConfig reads .issueify/rules.yaml, returning a zero-value (all
+// rules disabled) config if the file does not exist.
+func loadRulesConfig() (*rulesConfig, error) {
+	root, err := getRepoRoot()
+	if err != nil {
+		return nil, fmt.Errorf("could not find repository root: %w", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(root, ".issueify", "rules.yaml"))
+	if os.IsNotExist(err) {
+		return &rulesConfig{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read .issueify/rules.yaml: %w", err)
+	}
+
+	var cfg rulesConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse .issueify/rules.yaml: %w", err)
+	}
+	return &cfg, nil
+}
+
+// buildRules turns a rulesConfig into the list of rules run-bots applies,
+// in a fixed order: freezing, labeling, closing, then milestoning.
+func buildRules(cfg *rulesConfig) ([]rule, error) {
+	var rules []rule
+
+	if cfg.Rules.FreezeStale.Enabled {
+		rules = append(rules, freezeIdleRule(cfg.Rules.FreezeStale.IdleDays))
+	}
+
+	if cfg.Rules.AutoLabel.Enabled {
+		patterns := defaultLabelPatterns
+		if len(cfg.Rules.AutoLabel.Patterns) > 0 {
+			patterns = make([]LabelPattern, 0, len(cfg.Rules.AutoLabel.Patterns))
+			for _, p := range cfg.Rules.AutoLabel.Patterns {
+				re, err := regexp.Compile(p.Pattern)
+				if err != nil {
+					return nil, fmt.Errorf("invalid auto_label pattern %q: %w", p.Pattern, err)
+				}
+				patterns = append(patterns, LabelPattern{Pattern: re, Label: p.Label})
+			}
+		}
+		rules = append(rules, autoLabelRule(patterns))
+	}
+
+	if cfg.Rules.AutoClose.Enabled {
+		rules = append(rules, autoCloseStaleRule(cfg.Rules.AutoClose.IdleDays))
+	}
+
+	if cfg.Rules.AutoMilestone.Enabled {
+		rules = append(rules, autoAssignMilestoneRule(cfg.Rules.AutoMilestone.ByLabel))
+	}
+
+	return rules, nil
+}
+
+// lastActivity approximates when an issue was last touched, used by the
+// idle-based rules: its creation time, or its most recent comment if later.
+func lastActivity(issue Issue) time.Time {
+	last := issue.CreatedAt
+	for _, c := range issue.Comments {
+		if c.CreatedAt.After(last) {
+			last = c.CreatedAt
+		}
+	}
+	return last
+}
+
+// freezeIdleRule locks open issues that have seen no activity in idleDays.
+// Locking has no local-only counterpart on Backend (GitHub's lock endpoint
+// is a different API surface than UpdateIssue), so unlike the other rules
+// this one only ever affects the local database, even when store.Backend is
+// configured.
+func freezeIdleRule(idleDays int) rule {
+	return rule{
+		name: "freeze_stale",
+		fn: func(ctx context.Context, store *Store) error {
+			cutoff := time.Now().AddDate(0, 0, -idleDays)
+			for i := range store.Issues {
+				issue := &store.Issues[i]
+				if issue.Status != statusOpen || issue.Locked || lastActivity(*issue).After(cutoff) {
+					continue
+				}
+				if store.DryRun {
+					fmt.Printf("[dry-run] would freeze idle issue #%d\n", issue.ID)
+					continue
+				}
+				issue.Locked = true
+				fmt.Printf("Froze idle issue #%d\n", issue.ID)
+			}
+			return nil
+		},
+	}
+}
+
+// autoLabelRule applies regex-based label patterns to every issue's title,
+// generalizing the patterns addIssue applies at creation time to the whole
+// database. It also mirrors added labels to the remote issue when a backend
+// is available.
+func autoLabelRule(patterns []LabelPattern) rule {
+	return rule{
+		name: "auto_label",
+		fn: func(ctx context.Context, store *Store) error {
+			for i := range store.Issues {
+				issue := &store.Issues[i]
+
+				labelSet := make(map[string]bool)
+				for _, l := range issue.Labels {
+					labelSet[l] = true
+				}
+
+				var added []string
+				for _, lp := range patterns {
+					if lp.Pattern.MatchString(issue.Title) && !labelSet[lp.Label] {
+						added = append(added, lp.Label)
+						labelSet[lp.Label] = true
+					}
+				}
+				if len(added) == 0 {
+					continue
+				}
+
+				if store.DryRun {
+					fmt.Printf("[dry-run] would label issue #%d with %s\n", issue.ID, strings.Join(added, ", "))
+					continue
+				}
+				issue.Labels = append(issue.Labels, added...)
+				sort.Strings(issue.Labels)
+				fmt.Printf("Labeled issue #%d with %s\n", issue.ID, strings.Join(added, ", "))
+
+				if store.Backend != nil && issue.RemoteID != 0 {
+					for _, label := range added {
+						if err := store.Backend.EnsureLabel(ctx, label); err != nil {
+							log.Printf("Warning: could not create remote label %q: %v", label, err)
+						}
+					}
+					if err := store.Backend.UpdateIssue(ctx, issue.RemoteID, *issue); err != nil {
+						log.Printf("Warning: could not update remote issue #%d: %v", issue.RemoteID, err)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// autoCloseStaleRule closes open issues that have seen no activity in
+// idleDays, also closing the remote issue when a backend is available.
+func autoCloseStaleRule(idleDays int) rule {
+	return rule{
+		name: "auto_close",
+		fn: func(ctx context.Context, store *Store) error {
+			cutoff := time.Now().AddDate(0, 0, -idleDays)
+			for i := range store.Issues {
+				issue := &store.Issues[i]
+				if issue.Status != statusOpen || lastActivity(*issue).After(cutoff) {
+					continue
+				}
+
+				if store.DryRun {
+					fmt.Printf("[dry-run] would auto-close stale issue #%d\n", issue.ID)
+					continue
+				}
+
+				issue.Status = statusClosed
+				fmt.Printf("Auto-closed stale issue #%d\n", issue.ID)
+
+				if store.Backend != nil && issue.RemoteID != 0 {
+					if err := store.Backend.CloseIssue(ctx, issue.RemoteID); err != nil {
+						log.Printf("Warning: could not close remote issue #%d: %v", issue.RemoteID, err)
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// autoAssignMilestoneRule sets an issue's milestone from the first label
+// that has a mapping in byLabel, leaving issues that already have one alone,
+// and mirrors the assignment to the remote issue when a backend is available.
+func autoAssignMilestoneRule(byLabel map[string]string) rule {
+	return rule{
+		name: "auto_milestone",
+		fn: func(ctx context.Context, store *Store) error {
+			for i := range store.Issues {
+				issue := &store.Issues[i]
+				if issue.Milestone != "" {
+					continue
+				}
+
+				for _, label := range issue.Labels {
+					milestone, ok := byLabel[label]
+					if !ok {
+						continue
+					}
+					if store.DryRun {
+						fmt.Printf("[dry-run] would set milestone %q on issue #%d (label %q)\n", milestone, issue.ID, label)
+						break
+					}
+					issue.Milestone = milestone
+					fmt.Printf("Set milestone %q on issue #%d (label %q)\n", milestone, issue.ID, label)
+
+					if store.Backend != nil && issue.RemoteID != 0 {
+						if err := store.Backend.UpdateIssue(ctx, issue.RemoteID, *issue); err != nil {
+							log.Printf("Warning: could not update remote issue #%d: %v", issue.RemoteID, err)
+						}
+					}
+					break
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// runBots applies every rule enabled in .issueify/rules.yaml to the local
+// issue database, and to the corresponding remote issues where a backend's
+// credentials are available.
+func runBots(dryRunFlag bool) {
+	cfg, err := loadRulesConfig()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	rules, err := buildRules(cfg)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	if len(rules) == 0 {
+		fmt.Println("No rules enabled in .issueify/rules.yaml.")
+		return
+	}
+
+	issues, err := loadIssues()
+	if err != nil {
+		log.Fatalf("Error loading issues: %v", err)
+	}
+
+	store := &Store{Issues: issues, DryRun: cfg.DryRun || dryRunFlag}
+
+	if backendName, err := configuredBackend(); err == nil {
+		if backend, err := newBackend(backendName); err == nil {
+			if _, _, err := backend.DetectRepo(); err == nil {
+				store.Backend = backend
+			}
+		}
+	}
+
+	ctx := context.Background()
+	for _, r := range rules {
+		fmt.Printf("Running rule %q...\n", r.name)
+		if err := r.fn(ctx, store); err != nil {
+			log.Printf("Error running rule %q: %v", r.name, err)
+		}
+	}
+
+	if store.DryRun {
+		fmt.Println("Dry run: no changes saved.")
+		return
+	}
+
+	if err := saveIssues(store.Issues); err != nil {
+		log.Fatalf("Error saving issues: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// fakeBackend is a minimal Backend test double that records the calls made
+// to it, so rule tests can assert whether a rule mirrored its local change
+// remotely without talking to any real API.
+type fakeBackend struct {
+	updated        []int
+	closed         []int
+	ensuredLabels  []string
+	updateIssueErr error
+}
+
+func (f *fakeBackend) DetectRepo() (string, string, error) { return "owner", "repo", nil }
+
+func (f *fakeBackend) CreateIssue(ctx context.Context, issue Issue) (int, string, error) {
+	return 0, "", nil
+}
+
+func (f *fakeBackend) UpdateIssue(ctx context.Context, remoteID int, issue Issue) error {
+	f.updated = append(f.updated, remoteID)
+	return f.updateIssueErr
+}
+
+func (f *fakeBackend) CloseIssue(ctx context.Context, remoteID int) error {
+	f.closed = append(f.closed, remoteID)
+	return nil
+}
+
+func (f *fakeBackend) EnsureLabel(ctx context.Context, label string) error {
+	f.ensuredLabels = append(f.ensuredLabels, label)
+	return nil
+}
+
+var _ Backend = (*fakeBackend)(nil)
+
+func daysAgo(n int) time.Time {
+	return time.Now().AddDate(0, 0, -n)
+}
+
+func TestFreezeIdleRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		issue      Issue
+		idleDays   int
+		wantLocked bool
+	}{
+		{"idle open issue is frozen", Issue{Status: statusOpen, CreatedAt: daysAgo(10)}, 7, true},
+		{"just inside the cutoff is not frozen", Issue{Status: statusOpen, CreatedAt: daysAgo(6)}, 7, false},
+		{"closed issue is never frozen", Issue{Status: statusClosed, CreatedAt: daysAgo(10)}, 7, false},
+		{"already locked issue is left alone", Issue{Status: statusOpen, CreatedAt: daysAgo(10), Locked: true}, 7, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &Store{Issues: []Issue{tt.issue}}
+			if err := freezeIdleRule(tt.idleDays).fn(context.Background(), store); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if store.Issues[0].Locked != tt.wantLocked {
+				t.Errorf("Locked = %v, want %v", store.Issues[0].Locked, tt.wantLocked)
+			}
+		})
+	}
+}
+
+func TestFreezeIdleRuleDryRun(t *testing.T) {
+	store := &Store{Issues: []Issue{{Status: statusOpen, CreatedAt: daysAgo(10)}}, DryRun: true}
+	if err := freezeIdleRule(7).fn(context.Background(), store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.Issues[0].Locked {
+		t.Error("dry-run must not mutate Locked")
+	}
+}
+
+func TestAutoLabelRule(t *testing.T) {
+	patterns := []LabelPattern{
+		{Pattern: regexp.MustCompile(`(?i)^BUG:`), Label: "bug"},
+	}
+
+	backend := &fakeBackend{}
+	store := &Store{
+		Issues: []Issue{
+			{ID: 1, Title: "BUG: crash on start", RemoteID: 42},
+			{ID: 2, Title: "unrelated title"},
+			{ID: 3, Title: "BUG: already labeled", Labels: []string{"bug"}},
+		},
+		Backend: backend,
+	}
+
+	if err := autoLabelRule(patterns).fn(context.Background(), store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if labels := store.Issues[0].Labels; len(labels) != 1 || labels[0] != "bug" {
+		t.Errorf("issue #1 Labels = %v, want [bug]", labels)
+	}
+	if labels := store.Issues[1].Labels; len(labels) != 0 {
+		t.Errorf("issue #2 Labels = %v, want none added", labels)
+	}
+	if labels := store.Issues[2].Labels; len(labels) != 1 {
+		t.Errorf("issue #3 Labels = %v, want unchanged", labels)
+	}
+
+	if len(backend.updated) != 1 || backend.updated[0] != 42 {
+		t.Errorf("backend.updated = %v, want [42] (only the issue with a RemoteID)", backend.updated)
+	}
+	if len(backend.ensuredLabels) != 1 || backend.ensuredLabels[0] != "bug" {
+		t.Errorf("backend.ensuredLabels = %v, want [bug]", backend.ensuredLabels)
+	}
+}
+
+func TestAutoLabelRuleNoBackend(t *testing.T) {
+	patterns := []LabelPattern{{Pattern: regexp.MustCompile(`(?i)^BUG:`), Label: "bug"}}
+	store := &Store{Issues: []Issue{{ID: 1, Title: "BUG: crash", RemoteID: 42}}}
+
+	if err := autoLabelRule(patterns).fn(context.Background(), store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if labels := store.Issues[0].Labels; len(labels) != 1 {
+		t.Errorf("Labels = %v, want [bug] even without a backend", labels)
+	}
+}
+
+func TestAutoCloseStaleRule(t *testing.T) {
+	backend := &fakeBackend{}
+	store := &Store{
+		Issues: []Issue{
+			{ID: 1, Status: statusOpen, CreatedAt: daysAgo(10), RemoteID: 7},
+			{ID: 2, Status: statusOpen, CreatedAt: daysAgo(1)},
+		},
+		Backend: backend,
+	}
+
+	if err := autoCloseStaleRule(7).fn(context.Background(), store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if store.Issues[0].Status != statusClosed {
+		t.Errorf("issue #1 Status = %q, want closed", store.Issues[0].Status)
+	}
+	if store.Issues[1].Status != statusOpen {
+		t.Errorf("issue #2 Status = %q, want unchanged open", store.Issues[1].Status)
+	}
+	if len(backend.closed) != 1 || backend.closed[0] != 7 {
+		t.Errorf("backend.closed = %v, want [7]", backend.closed)
+	}
+}
+
+func TestAutoAssignMilestoneRule(t *testing.T) {
+	byLabel := map[string]string{"bug": "v1.1"}
+	backend := &fakeBackend{}
+	store := &Store{
+		Issues: []Issue{
+			{ID: 1, Labels: []string{"bug"}, RemoteID: 5},
+			{ID: 2, Labels: []string{"feature"}},
+			{ID: 3, Labels: []string{"bug"}, Milestone: "already-set"},
+		},
+		Backend: backend,
+	}
+
+	if err := autoAssignMilestoneRule(byLabel).fn(context.Background(), store); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := store.Issues[0].Milestone; got != "v1.1" {
+		t.Errorf("issue #1 Milestone = %q, want v1.1", got)
+	}
+	if got := store.Issues[1].Milestone; got != "" {
+		t.Errorf("issue #2 Milestone = %q, want empty (no mapped label)", got)
+	}
+	if got := store.Issues[2].Milestone; got != "already-set" {
+		t.Errorf("issue #3 Milestone = %q, want left alone", got)
+	}
+	if len(backend.updated) != 1 || backend.updated[0] != 5 {
+		t.Errorf("backend.updated = %v, want [5] (only the issue with a RemoteID)", backend.updated)
+	}
+}
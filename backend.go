@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Backend is a pluggable remote issue tracker that issue-tracker can push
+// local issues to. Concrete implementations exist for GitHub, GitLab, and
+// Gitea; the active one is chosen via --backend=<name> on the CLI or the
+// backend key in .issueify/config, defaulting to "github".
+type Backend interface {
+	// DetectRepo resolves the owner/repo and authentication for this
+	// backend, preferring the platform's own CLI ('gh', 'glab', or 'tea')
+	// and falling back to environment variables. It must be called before
+	// any other method.
+	DetectRepo() (owner, repo string, err error)
+
+	// CreateIssue creates a new remote issue and returns its ID and URL.
+	CreateIssue(ctx context.Context, issue Issue) (remoteID int, url string, err error)
+
+	// UpdateIssue patches an existing remote issue's title and labels.
+	UpdateIssue(ctx context.Context, remoteID int, issue Issue) error
+
+	// CloseIssue closes a remote issue.
+	CloseIssue(ctx context.Context, remoteID int) error
+
+	// EnsureLabel creates the label on the remote repository if it does not
+	// already exist.
+	EnsureLabel(ctx context.Context, label string) error
+}
+
+// backendNames lists the backends issue-tracker supports, in the order they
+// should be presented to users.
+var backendNames = []string{"github", "gitlab", "gitea"}
+
+// newBackend constructs the Backend for the given name. An empty name
+// selects the default, "github".
+func newBackend(name string) (Backend, error) {
+	switch name {
+	case "", "github":
+		return &githubBackend{}, nil
+	case "gitlab":
+		return &gitlabBackend{}, nil
+	case "gitea":
+		return &giteaBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown backend %q (supported: %s)", name, strings.Join(backendNames, ", "))
+	}
+}
+
+// configuredBackend reads the "backend" key out of .issueify/config,
+// returning an empty string if the file or key is absent.
+func configuredBackend() (string, error) {
+	root, err := getRepoRoot()
+	if err != nil {
+		return "", nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(root, ".issueify", "config"))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("could not read .issueify/config: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if name, ok := strings.CutPrefix(line, "backend="); ok {
+			return strings.TrimSpace(name), nil
+		}
+	}
+	return "", nil
+}
+
+// gitRemoteURLPattern matches the git@host:path.git, ssh://[user@]host/path,
+// and https://[user@]host/path[.git] remote URL forms. path is one or more
+// "/"-separated segments so nested GitLab group paths like
+// "group/subgroup/repo" parse too; the last segment is the repo and
+// everything before it is the owner.
+var gitRemoteURLPattern = regexp.MustCompile(`^(?:(?:https?|ssh)://(?:[^@/]+@)?|git@)([^:/]+)[:/]((?:[\w.-]+/)+)([\w.-]+?)(?:\.git)?/?$`)
+
+// repoFromGitRemote extracts the host and "owner/repo" pair from a git
+// remote's URL. It is used by backends whose CLI (glab, tea) relies on the
+// local git config to find the current repo rather than querying the
+// platform directly.
+func repoFromGitRemote(remoteName string) (host, owner, repo string, err error) {
+	out, err := exec.Command("git", "remote", "get-url", remoteName).Output()
+	if err != nil {
+		return "", "", "", fmt.Errorf("could not read git remote %q: %w", remoteName, err)
+	}
+
+	url := strings.TrimSpace(string(out))
+	match := gitRemoteURLPattern.FindStringSubmatch(url)
+	if match == nil {
+		return "", "", "", fmt.Errorf("could not parse owner/repo from remote URL %q", url)
+	}
+	return match[1], strings.TrimSuffix(match[2], "/"), match[3], nil
+}
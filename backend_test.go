@@ -0,0 +1,153 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitRemoteURLPattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantHost  string
+		wantOwner string
+		wantRepo  string
+		wantMatch bool
+	}{
+		{"https with .git", "https://github.com/owner/repo.git", "github.com", "owner", "repo", true},
+		{"https without .git", "https://github.com/owner/repo", "github.com", "owner", "repo", true},
+		{"https with user", "https://user@github.com/owner/repo.git", "github.com", "owner", "repo", true},
+		{"scp-like git@", "git@github.com:owner/repo.git", "github.com", "owner", "repo", true},
+		{"ssh scheme", "ssh://git@github.com/owner/repo.git", "github.com", "owner", "repo", true},
+		{"ssh scheme with port-free host", "ssh://git@example.com/owner/repo", "example.com", "owner", "repo", true},
+		{"nested gitlab group over scp-like", "git@gitlab.example.com:group/subgroup/repo.git", "gitlab.example.com", "group/subgroup", "repo", true},
+		{"nested gitlab group over https", "https://gitlab.example.com/group/subgroup/repo.git", "gitlab.example.com", "group/subgroup", "repo", true},
+		{"trailing slash", "https://github.com/owner/repo/", "github.com", "owner", "repo", true},
+		{"not a remote URL", "not a url", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := gitRemoteURLPattern.FindStringSubmatch(tt.url)
+			if !tt.wantMatch {
+				if match != nil {
+					t.Fatalf("FindStringSubmatch(%q) = %v, want no match", tt.url, match)
+				}
+				return
+			}
+			if match == nil {
+				t.Fatalf("FindStringSubmatch(%q) = no match, want host=%q owner=%q repo=%q", tt.url, tt.wantHost, tt.wantOwner, tt.wantRepo)
+			}
+
+			host, owner, repo := match[1], trimTrailingSlash(match[2]), match[3]
+			if host != tt.wantHost || owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("got host=%q owner=%q repo=%q, want host=%q owner=%q repo=%q", host, owner, repo, tt.wantHost, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+// trimTrailingSlash mirrors the trim repoFromGitRemote applies to the owner
+// capture group, so this test can assert against it without duplicating
+// repoFromGitRemote's git-shelling-out behavior.
+func trimTrailingSlash(s string) string {
+	if len(s) > 0 && s[len(s)-1] == '/' {
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+func TestRepoFromGitRemote(t *testing.T) {
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	run("remote", "add", "origin", "git@gitlab.example.com:group/subgroup/repo.git")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("os.Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("os.Chdir: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	host, owner, repo, err := repoFromGitRemote("origin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if host != "gitlab.example.com" || owner != "group/subgroup" || repo != "repo" {
+		t.Errorf("got host=%q owner=%q repo=%q, want host=gitlab.example.com owner=group/subgroup repo=repo", host, owner, repo)
+	}
+}
+
+func TestRepoFromGitRemoteMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("os.Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("os.Chdir: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	if _, _, _, err := repoFromGitRemote("origin"); err == nil {
+		t.Fatal("expected an error for a repo with no 'origin' remote, got nil")
+	}
+}
+
+func TestConfiguredBackend(t *testing.T) {
+	dir := t.TempDir()
+
+	cmd := exec.Command("git", "init", "-q")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("os.Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("os.Chdir: %v", err)
+	}
+	defer os.Chdir(cwd)
+
+	if got, err := configuredBackend(); err != nil || got != "" {
+		t.Fatalf("with no config file: got %q, %v, want \"\", nil", got, err)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, ".issueify"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "# a comment\n\nbackend=gitlab\n"
+	if err := os.WriteFile(filepath.Join(dir, ".issueify", "config"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := configuredBackend()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "gitlab" {
+		t.Errorf("configuredBackend() = %q, want %q", got, "gitlab")
+	}
+}
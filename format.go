@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// ANSI escape codes used by the %sC and %Creset format placeholders.
+const (
+	ansiReset = "\033[0m"
+	ansiGreen = "\033[32m"
+	ansiRed   = "\033[31m"
+	ansiCyan  = "\033[36m"
+)
+
+// Named formats predefined for --format, matching hub's -f shorthand.
+const (
+	formatShort = "%sC%S%Creset\t#%I\t%t%n"
+	formatTSV   = "%I\t%t\t%S\t%L%n"
+)
+
+// formatToken is one %-placeholder recognized by expandFormat. Tokens that
+// are a prefix of another (like %sC and %S) are ordered longest first so
+// the tokenizer matches the more specific one.
+type formatToken struct {
+	token string
+	fn    func(issue Issue) string
+}
+
+var formatTokens = []formatToken{
+	{"%Creset", func(Issue) string { return ansiReset }},
+	{"%sC", func(issue Issue) string { return stateColor(issue.Status) }},
+	{"%cD", func(issue Issue) string { return issue.CreatedAt.Format("2006-01-02") }},
+	{"%au", func(issue Issue) string { return issueAuthor(issue) }},
+	{"%I", func(issue Issue) string { return strconv.Itoa(issue.ID) }},
+	{"%t", func(issue Issue) string { return issue.Title }},
+	{"%S", func(issue Issue) string { return issue.Status }},
+	{"%l", func(issue Issue) string { return coloredLabels(issue.Labels) }},
+	{"%L", func(issue Issue) string { return strings.Join(issue.Labels, ",") }},
+	{"%n", func(Issue) string { return "\n" }},
+}
+
+func stateColor(status string) string {
+	if status == statusClosed {
+		return ansiRed
+	}
+	return ansiGreen
+}
+
+func coloredLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return ansiCyan + strings.Join(labels, ", ") + ansiReset
+}
+
+func issueAuthor(issue Issue) string {
+	if issue.Author == "" {
+		return "-"
+	}
+	return issue.Author
+}
+
+// expandFormat walks tmpl, expanding every recognized %-placeholder for
+// issue and passing through any other character, including unrecognized
+// '%' sequences, unchanged.
+func expandFormat(tmpl string, issue Issue) string {
+	var b strings.Builder
+	for i := 0; i < len(tmpl); {
+		if tmpl[i] == '%' {
+			matched := false
+			for _, t := range formatTokens {
+				if strings.HasPrefix(tmpl[i:], t.token) {
+					b.WriteString(t.fn(issue))
+					i += len(t.token)
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+		}
+		b.WriteByte(tmpl[i])
+		i++
+	}
+	return b.String()
+}
+
+// printFormatted renders issues per format, which is either one of the
+// predefined names (short, json, tsv) or a literal %-placeholder template.
+func printFormatted(issues []Issue, format string) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			log.Fatalf("Error formatting issues as JSON: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	case "short":
+		format = formatShort
+	case "tsv":
+		format = formatTSV
+	}
+
+	for _, issue := range issues {
+		fmt.Print(expandFormat(format, issue))
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandFormatTokens(t *testing.T) {
+	issue := Issue{
+		ID:        7,
+		Title:     "fix crash",
+		Status:    statusOpen,
+		Labels:    []string{"bug", "urgent"},
+		CreatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Author:    "jdoe",
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{"id", "%I", "7"},
+		{"title", "%t", "fix crash"},
+		{"status", "%S", "open"},
+		{"labels joined", "%L", "bug,urgent"},
+		{"created date", "%cD", "2026-01-02"},
+		{"author", "%au", "jdoe"},
+		{"newline", "%n", "\n"},
+		{"literal text passes through", "#%I: %t", "#7: fix crash"},
+		{"unrecognized percent sequence is left alone", "100%% done", "100%% done"},
+		{"state color resolves to the longer %sC token, not %S", "%sC", ansiGreen},
+		{"Creset resolves to the longer %Creset token, not a stray %C", "%Creset", ansiReset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandFormat(tt.tmpl, issue); got != tt.want {
+				t.Errorf("expandFormat(%q) = %q, want %q", tt.tmpl, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandFormatClosedStateColor(t *testing.T) {
+	issue := Issue{Status: statusClosed}
+	if got := expandFormat("%sC", issue); got != ansiRed {
+		t.Errorf("expandFormat(%%sC) on a closed issue = %q, want %q", got, ansiRed)
+	}
+}
+
+func TestExpandFormatMissingAuthor(t *testing.T) {
+	issue := Issue{}
+	if got := expandFormat("%au", issue); got != "-" {
+		t.Errorf("expandFormat(%%au) with no author = %q, want %q", got, "-")
+	}
+}
+
+func TestExpandFormatNoLabels(t *testing.T) {
+	issue := Issue{}
+	if got := expandFormat("%l", issue); got != "" {
+		t.Errorf("expandFormat(%%l) with no labels = %q, want empty", got)
+	}
+}
+
+func TestExpandFormatNamedFormats(t *testing.T) {
+	issue := Issue{ID: 1, Title: "hello", Status: statusOpen}
+
+	if got := expandFormat(formatTSV, issue); got != "1\thello\topen\t\n" {
+		t.Errorf("expandFormat(formatTSV) = %q", got)
+	}
+}
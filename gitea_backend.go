@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// giteaBackend implements Backend against the Gitea REST API via
+// code.gitea.io/sdk/gitea. The 'tea' CLI does not expose its stored tokens
+// (for security), so unlike the other backends only repository detection
+// falls back to the local git remote; authentication always comes from
+// environment variables. Its methods are safe to call concurrently, as
+// pushIssues does: mu guards the label ID cache shared across goroutines.
+type giteaBackend struct {
+	client *gitea.Client
+	owner  string
+	repo   string
+
+	mu       sync.Mutex
+	labelIDs map[string]int64
+}
+
+func (b *giteaBackend) DetectRepo() (owner, repo string, err error) {
+	baseURL := os.Getenv("GITEA_URL")
+	owner = os.Getenv("GITEA_OWNER")
+	repo = os.Getenv("GITEA_REPO")
+
+	if owner == "" || repo == "" {
+		if host, gitOwner, gitRepo, gitErr := repoFromGitRemote("origin"); gitErr == nil {
+			if baseURL == "" {
+				baseURL = "https://" + host
+			}
+			owner, repo = gitOwner, gitRepo
+		}
+	}
+
+	token := os.Getenv("GITEA_TOKEN")
+
+	if baseURL == "" || token == "" || owner == "" || repo == "" {
+		return "", "", fmt.Errorf("please authenticate the 'tea' CLI ('tea login add') and ensure 'origin' points at the repository, or set GITEA_URL, GITEA_TOKEN, GITEA_OWNER, and GITEA_REPO environment variables")
+	}
+
+	client, err := gitea.NewClient(baseURL, gitea.SetToken(token))
+	if err != nil {
+		return "", "", fmt.Errorf("could not create Gitea client: %w", err)
+	}
+
+	b.client = client
+	b.owner = owner
+	b.repo = repo
+	b.labelIDs = make(map[string]int64)
+
+	fmt.Printf("Detected repository '%s/%s' at %s.\n", owner, repo, baseURL)
+	return owner, repo, nil
+}
+
+// labelIDsFor resolves each label name to its Gitea label ID, creating any
+// label that EnsureLabel hasn't already cached.
+func (b *giteaBackend) labelIDsFor(ctx context.Context, labels []string) ([]int64, error) {
+	ids := make([]int64, 0, len(labels))
+	for _, label := range labels {
+		if err := b.EnsureLabel(ctx, label); err != nil {
+			return nil, err
+		}
+		b.mu.Lock()
+		id := b.labelIDs[label]
+		b.mu.Unlock()
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (b *giteaBackend) CreateIssue(ctx context.Context, issue Issue) (remoteID int, url string, err error) {
+	labelIDs, err := b.labelIDsFor(ctx, issue.Labels)
+	if err != nil {
+		return 0, "", err
+	}
+
+	created, _, err := b.client.CreateIssue(b.owner, b.repo, gitea.CreateIssueOption{
+		Title:  issue.Title,
+		Body:   issue.Body,
+		Labels: labelIDs,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("could not create Gitea issue: %w", err)
+	}
+	return int(created.Index), created.HTMLURL, nil
+}
+
+func (b *giteaBackend) UpdateIssue(ctx context.Context, remoteID int, issue Issue) error {
+	labelIDs, err := b.labelIDsFor(ctx, issue.Labels)
+	if err != nil {
+		return err
+	}
+
+	body := issue.Body
+	if _, _, err := b.client.EditIssue(b.owner, b.repo, int64(remoteID), gitea.EditIssueOption{Title: issue.Title, Body: &body}); err != nil {
+		return fmt.Errorf("could not update Gitea issue #%d: %w", remoteID, err)
+	}
+	if _, _, err := b.client.ReplaceIssueLabels(b.owner, b.repo, int64(remoteID), gitea.IssueLabelsOption{Labels: labelIDs}); err != nil {
+		return fmt.Errorf("could not update labels on Gitea issue #%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+func (b *giteaBackend) CloseIssue(ctx context.Context, remoteID int) error {
+	closed := gitea.StateClosed
+	if _, _, err := b.client.EditIssue(b.owner, b.repo, int64(remoteID), gitea.EditIssueOption{State: &closed}); err != nil {
+		return fmt.Errorf("could not close Gitea issue #%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+// EnsureLabel makes sure a label exists on the Gitea repository, creating
+// it the first time it is seen, and caches its ID for labelIDsFor.
+func (b *giteaBackend) EnsureLabel(ctx context.Context, label string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, known := b.labelIDs[label]; known {
+		return nil
+	}
+
+	existing, _, err := b.client.ListRepoLabels(b.owner, b.repo, gitea.ListLabelsOptions{})
+	if err == nil {
+		for _, l := range existing {
+			if l.Name == label {
+				b.labelIDs[label] = l.ID
+				return nil
+			}
+		}
+	}
+
+	created, _, err := b.client.CreateLabel(b.owner, b.repo, gitea.CreateLabelOption{Name: label, Color: "#428BCA"})
+	if err != nil {
+		return fmt.Errorf("could not create Gitea label %q: %w", label, err)
+	}
+	b.labelIDs[label] = created.ID
+	return nil
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"golang.org/x/oauth2"
+)
+
+// githubBackend implements Backend against the GitHub REST API via
+// google/go-github, authenticating with the 'gh' CLI when available. Its
+// methods are safe to call concurrently, as pushIssues does: mu guards the
+// label/milestone caches shared across goroutines.
+type githubBackend struct {
+	client *github.Client
+	owner  string
+	repo   string
+
+	mu           sync.Mutex
+	knownLabels  map[string]bool
+	milestoneIDs map[string]int
+}
+
+// DetectRepo resolves the repository and an auth token to talk to the
+// GitHub API. It will try to use the 'gh' CLI for repository info and
+// authentication first. If 'gh' is not available, it will fall back to
+// environment variables.
+func (b *githubBackend) DetectRepo() (owner, repo string, err error) {
+	var token string
+
+	ghRepoCmd := exec.Command("gh", "repo", "view", "--json", "name,owner", "--jq", ".owner.login + \"/\" + .name")
+	if ghRepoOutput, cmdErr := ghRepoCmd.Output(); cmdErr == nil {
+		repoParts := strings.Split(strings.TrimSpace(string(ghRepoOutput)), "/")
+		if len(repoParts) == 2 {
+			owner = repoParts[0]
+			repo = repoParts[1]
+		}
+	}
+
+	if ghTokenOutput, cmdErr := exec.Command("gh", "auth", "token").Output(); cmdErr == nil {
+		token = strings.TrimSpace(string(ghTokenOutput))
+	}
+
+	if owner != "" && repo != "" && token != "" {
+		fmt.Printf("Detected repository '%s/%s' and using auth token from 'gh' CLI.\n", owner, repo)
+	} else {
+		fmt.Println("Could not get repository info or token from 'gh' CLI. Falling back to environment variables.")
+		token = os.Getenv("GITHUB_TOKEN")
+		owner = os.Getenv("GITHUB_OWNER")
+		repo = os.Getenv("GITHUB_REPO")
+
+		if token == "" || owner == "" || repo == "" {
+			return "", "", fmt.Errorf("please install and authenticate the 'gh' CLI ('gh auth login'), or set GITHUB_TOKEN, GITHUB_OWNER, and GITHUB_REPO environment variables")
+		}
+	}
+
+	ctx := context.Background()
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	tc := oauth2.NewClient(ctx, ts)
+
+	b.client = github.NewClient(tc)
+	b.owner = owner
+	b.repo = repo
+	b.knownLabels = make(map[string]bool)
+	b.milestoneIDs = make(map[string]int)
+
+	return owner, repo, nil
+}
+
+func (b *githubBackend) CreateIssue(ctx context.Context, issue Issue) (remoteID int, url string, err error) {
+	gitIssue, err := b.buildIssueRequest(ctx, issue)
+	if err != nil {
+		return 0, "", err
+	}
+
+	created, _, err := b.client.Issues.Create(ctx, b.owner, b.repo, gitIssue)
+	if err != nil {
+		return 0, "", fmt.Errorf("could not create GitHub issue: %w", err)
+	}
+	return created.GetNumber(), created.GetHTMLURL(), nil
+}
+
+func (b *githubBackend) UpdateIssue(ctx context.Context, remoteID int, issue Issue) error {
+	gitIssue, err := b.buildIssueRequest(ctx, issue)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := b.client.Issues.Edit(ctx, b.owner, b.repo, remoteID, gitIssue); err != nil {
+		return fmt.Errorf("could not update GitHub issue #%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+// buildIssueRequest maps an Issue onto the fields GitHub's issue API
+// accepts, resolving Milestone's name to the numeric ID GitHub expects.
+func (b *githubBackend) buildIssueRequest(ctx context.Context, issue Issue) (*github.IssueRequest, error) {
+	gitIssue := &github.IssueRequest{
+		Title:     &issue.Title,
+		Body:      &issue.Body,
+		Labels:    &issue.Labels,
+		Assignees: &issue.Assignees,
+	}
+
+	if issue.Milestone != "" {
+		id, err := b.resolveMilestone(ctx, issue.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		gitIssue.Milestone = &id
+	}
+
+	return gitIssue, nil
+}
+
+// resolveMilestone looks up a milestone's number by title, caching results
+// so repeated issues sharing a milestone don't each pay for a lookup.
+func (b *githubBackend) resolveMilestone(ctx context.Context, title string) (int, error) {
+	b.mu.Lock()
+	id, ok := b.milestoneIDs[title]
+	b.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	milestones, _, err := b.client.Issues.ListMilestones(ctx, b.owner, b.repo, nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not list milestones: %w", err)
+	}
+
+	b.mu.Lock()
+	for _, m := range milestones {
+		b.milestoneIDs[m.GetTitle()] = m.GetNumber()
+	}
+	id, ok = b.milestoneIDs[title]
+	b.mu.Unlock()
+
+	if !ok {
+		return 0, fmt.Errorf("milestone %q does not exist on %s/%s", title, b.owner, b.repo)
+	}
+	return id, nil
+}
+
+// RateLimitDelay recognizes GitHub's primary and secondary rate-limit
+// errors and reports how long pushIssues' worker pool should wait before
+// retrying, implementing rateLimitWaiter.
+func (b *githubBackend) RateLimitDelay(err error) (time.Duration, bool) {
+	var rlErr *github.RateLimitError
+	if errors.As(err, &rlErr) {
+		delay := time.Until(rlErr.Rate.Reset.Time)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if abuseErr.RetryAfter != nil {
+			return *abuseErr.RetryAfter, true
+		}
+		return time.Minute, true
+	}
+
+	return 0, false
+}
+
+var _ rateLimitWaiter = (*githubBackend)(nil)
+
+// PostComment adds a comment to a remote issue.
+func (b *githubBackend) PostComment(ctx context.Context, remoteID int, comment Comment) error {
+	body := fmt.Sprintf("**%s**: %s", comment.Author, comment.Text)
+	if _, _, err := b.client.Issues.CreateComment(ctx, b.owner, b.repo, remoteID, &github.IssueComment{Body: &body}); err != nil {
+		return fmt.Errorf("could not post comment on GitHub issue #%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+var _ commentPoster = (*githubBackend)(nil)
+
+func (b *githubBackend) CloseIssue(ctx context.Context, remoteID int) error {
+	state := statusClosed
+	gitIssue := &github.IssueRequest{State: &state}
+
+	if _, _, err := b.client.Issues.Edit(ctx, b.owner, b.repo, remoteID, gitIssue); err != nil {
+		return fmt.Errorf("could not close GitHub issue #%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+// EnsureLabel makes sure a label exists on the remote repository, creating
+// it via the GitHub API the first time it is seen. Labels already confirmed
+// to exist are cached so repeated issues don't each pay for a lookup.
+func (b *githubBackend) EnsureLabel(ctx context.Context, label string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.knownLabels[label] {
+		return nil
+	}
+
+	if _, _, err := b.client.Issues.GetLabel(ctx, b.owner, b.repo, label); err == nil {
+		b.knownLabels[label] = true
+		return nil
+	}
+
+	if _, _, err := b.client.Issues.CreateLabel(ctx, b.owner, b.repo, &github.Label{Name: &label}); err != nil {
+		return fmt.Errorf("could not create label %q: %w", label, err)
+	}
+	b.knownLabels[label] = true
+	return nil
+}
+
+// ListRemoteIssues fetches every issue (open and closed) in the repository.
+// githubBackend is the only backend that implements remoteLister today,
+// so `sync --pull` currently requires --backend=github.
+func (b *githubBackend) ListRemoteIssues(ctx context.Context) ([]RemoteIssue, error) {
+	var all []RemoteIssue
+
+	opts := &github.IssueListByRepoOptions{State: "all"}
+	for {
+		remoteIssues, resp, err := b.client.Issues.ListByRepo(ctx, b.owner, b.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("could not list GitHub issues: %w", err)
+		}
+
+		for _, ri := range remoteIssues {
+			if ri.IsPullRequest() {
+				continue
+			}
+
+			status := statusOpen
+			if ri.GetState() == "closed" {
+				status = statusClosed
+			}
+
+			labels := make([]string, 0, len(ri.Labels))
+			for _, l := range ri.Labels {
+				labels = append(labels, l.GetName())
+			}
+			sort.Strings(labels)
+
+			all = append(all, RemoteIssue{
+				RemoteID:  ri.GetNumber(),
+				RemoteURL: ri.GetHTMLURL(),
+				Title:     ri.GetTitle(),
+				Status:    status,
+				Labels:    labels,
+				CreatedAt: ri.GetCreatedAt(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+var _ remoteLister = (*githubBackend)(nil)
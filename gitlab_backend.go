@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// gitlabBackend implements Backend against the GitLab REST API via
+// xanzy/go-gitlab, authenticating with the 'glab' CLI when available. Its
+// methods are safe to call concurrently, as pushIssues does: mu guards the
+// label cache shared across goroutines.
+type gitlabBackend struct {
+	client      *gitlab.Client
+	projectPath string // "namespace/project", GitLab's project identifier
+
+	mu          sync.Mutex
+	knownLabels map[string]bool
+}
+
+// glabRepoView is the subset of `glab repo view -F json` this backend reads.
+type glabRepoView struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+// DetectRepo resolves the project and an auth token to talk to the GitLab
+// API. It will try the 'glab' CLI first, then fall back to the local git
+// remote for the project path and environment variables for the token.
+func (b *gitlabBackend) DetectRepo() (owner, repo string, err error) {
+	var token, projectPath string
+
+	if out, cmdErr := exec.Command("glab", "repo", "view", "-F", "json").Output(); cmdErr == nil {
+		var view glabRepoView
+		if json.Unmarshal(out, &view) == nil {
+			projectPath = view.PathWithNamespace
+		}
+	}
+	if out, cmdErr := exec.Command("glab", "auth", "token").Output(); cmdErr == nil {
+		token = strings.TrimSpace(string(out))
+	}
+
+	if projectPath != "" && token != "" {
+		fmt.Printf("Detected repository '%s' and using auth token from 'glab' CLI.\n", projectPath)
+	} else {
+		fmt.Println("Could not get repository info or token from 'glab' CLI. Falling back to the git remote and environment variables.")
+
+		if projectPath == "" {
+			if _, gitOwner, gitRepo, gitErr := repoFromGitRemote("origin"); gitErr == nil {
+				projectPath = gitOwner + "/" + gitRepo
+			}
+		}
+		if token == "" {
+			token = os.Getenv("GITLAB_TOKEN")
+		}
+		if projectPath == "" {
+			projectPath = os.Getenv("GITLAB_PROJECT")
+		}
+
+		if token == "" || projectPath == "" {
+			return "", "", fmt.Errorf("please install and authenticate the 'glab' CLI ('glab auth login'), or set GITLAB_TOKEN and GITLAB_PROJECT environment variables")
+		}
+	}
+
+	var client *gitlab.Client
+	if baseURL := os.Getenv("GITLAB_HOST"); baseURL != "" {
+		client, err = gitlab.NewClient(token, gitlab.WithBaseURL(baseURL))
+	} else {
+		client, err = gitlab.NewClient(token)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("could not create GitLab client: %w", err)
+	}
+
+	b.client = client
+	b.projectPath = projectPath
+	b.knownLabels = make(map[string]bool)
+
+	parts := strings.SplitN(projectPath, "/", 2)
+	owner = parts[0]
+	if len(parts) == 2 {
+		repo = parts[1]
+	}
+	return owner, repo, nil
+}
+
+func (b *gitlabBackend) CreateIssue(ctx context.Context, issue Issue) (remoteID int, url string, err error) {
+	labels := gitlab.LabelOptions(issue.Labels)
+	opt := &gitlab.CreateIssueOptions{
+		Title:       gitlab.String(issue.Title),
+		Description: gitlab.String(issue.Body),
+		Labels:      &labels,
+	}
+
+	created, _, err := b.client.Issues.CreateIssue(b.projectPath, opt, gitlab.WithContext(ctx))
+	if err != nil {
+		return 0, "", fmt.Errorf("could not create GitLab issue: %w", err)
+	}
+	return created.IID, created.WebURL, nil
+}
+
+func (b *gitlabBackend) UpdateIssue(ctx context.Context, remoteID int, issue Issue) error {
+	labels := gitlab.LabelOptions(issue.Labels)
+	opt := &gitlab.UpdateIssueOptions{
+		Title:       gitlab.String(issue.Title),
+		Description: gitlab.String(issue.Body),
+		Labels:      &labels,
+	}
+
+	if _, _, err := b.client.Issues.UpdateIssue(b.projectPath, remoteID, opt, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("could not update GitLab issue !%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+func (b *gitlabBackend) CloseIssue(ctx context.Context, remoteID int) error {
+	opt := &gitlab.UpdateIssueOptions{StateEvent: gitlab.String("close")}
+
+	if _, _, err := b.client.Issues.UpdateIssue(b.projectPath, remoteID, opt, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("could not close GitLab issue !%d: %w", remoteID, err)
+	}
+	return nil
+}
+
+// EnsureLabel makes sure a label exists on the GitLab project, creating it
+// the first time it is seen. Labels already confirmed to exist are cached
+// so repeated issues don't each pay for a lookup.
+func (b *gitlabBackend) EnsureLabel(ctx context.Context, label string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.knownLabels[label] {
+		return nil
+	}
+
+	if _, _, err := b.client.Labels.GetLabel(b.projectPath, label, gitlab.WithContext(ctx)); err == nil {
+		b.knownLabels[label] = true
+		return nil
+	}
+
+	opt := &gitlab.CreateLabelOptions{Name: gitlab.String(label), Color: gitlab.String("#428BCA")}
+	if _, _, err := b.client.Labels.CreateLabel(b.projectPath, opt, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("could not create GitLab label %q: %w", label, err)
+	}
+	b.knownLabels[label] = true
+	return nil
+}
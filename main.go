@@ -1,7 +1,6 @@
 package main
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -16,8 +15,7 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/google/go-github/v45/github"
-	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v3"
 )
 
 // --- Data Structures ---
@@ -29,6 +27,28 @@ type Issue struct {
 	Status    string    `json:"status"` // "open" or "closed"
 	Labels    []string  `json:"labels"`
 	CreatedAt time.Time `json:"created_at"`
+
+	Body      string    `json:"body,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Assignees []string  `json:"assignees,omitempty"`
+	Milestone string    `json:"milestone,omitempty"`
+	Priority  string    `json:"priority,omitempty"`
+	Comments  []Comment `json:"comments,omitempty"`
+	Locked    bool      `json:"locked,omitempty"`
+
+	// RemoteID, RemoteURL, and LastSyncedAt track the issue's mirror on
+	// the configured backend, if any. RemoteID is zero until the issue has
+	// been pushed.
+	RemoteID     int       `json:"remote_id,omitempty"`
+	RemoteURL    string    `json:"remote_url,omitempty"`
+	LastSyncedAt time.Time `json:"last_synced_at,omitempty"`
+}
+
+// Comment is a timestamped note attached to an Issue.
+type Comment struct {
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // LabelPattern defines a regex pattern to automatically assign a label.
@@ -40,10 +60,10 @@ type LabelPattern struct {
 // --- Constants and Configuration ---
 
 const (
-	dbFileName    = ".issue_tracker.json"
-	statusOpen    = "open"
-	statusClosed  = "closed"
-	markdownTpl   = `# Project Issues
+	dbFileName   = ".issue_tracker.json"
+	statusOpen   = "open"
+	statusClosed = "closed"
+	markdownTpl  = `# Project Issues
 
 {{range .}}
 - **[{{.Status}}]** {{.Title}} ` + "`[ID: {{.ID}}]`" + `
@@ -169,6 +189,7 @@ func addIssue(title string) {
 		Status:    statusOpen,
 		Labels:    []string{},
 		CreatedAt: time.Now(),
+		Author:    commentAuthor(),
 	}
 
 	autoLabel(&newIssue)
@@ -182,17 +203,13 @@ func addIssue(title string) {
 	fmt.Printf("Labels: %s\n", strings.Join(newIssue.Labels, ", "))
 }
 
-func listIssues(filterLabel string, showClosed bool) {
+func listIssues(filterLabel string, showClosed bool, format string) {
 	issues, err := loadIssues()
 	if err != nil {
 		log.Fatalf("Error loading issues: %v", err)
 	}
 
-	fmt.Println("--------------------------------------------------")
-	fmt.Println("                 Issue Tracker")
-	fmt.Println("--------------------------------------------------")
-
-	count := 0
+	var filtered []Issue
 	for _, issue := range issues {
 		if !showClosed && issue.Status == statusClosed {
 			continue
@@ -211,16 +228,28 @@ func listIssues(filterLabel string, showClosed bool) {
 			}
 		}
 
+		filtered = append(filtered, issue)
+	}
+
+	if format != "" {
+		printFormatted(filtered, format)
+		return
+	}
+
+	fmt.Println("--------------------------------------------------")
+	fmt.Println("                 Issue Tracker")
+	fmt.Println("--------------------------------------------------")
+
+	for _, issue := range filtered {
 		statusMarker := "✅"
 		if issue.Status == statusOpen {
 			statusMarker = "⚪️"
 		}
 
 		fmt.Printf("%s ID: %-3d | %-50s | Labels: %s\n", statusMarker, issue.ID, issue.Title, strings.Join(issue.Labels, ", "))
-		count++
 	}
 
-	if count == 0 {
+	if len(filtered) == 0 {
 		fmt.Println("No issues found.")
 	}
 	fmt.Println("--------------------------------------------------")
@@ -261,110 +290,264 @@ func closeIssue(idStr string) {
 	fmt.Printf("Successfully closed issue #%d.\n", id)
 }
 
-func publishIssues(format string) {
+func assignIssue(idStr, user string) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Fatalf("Error: Invalid ID format. Please provide a number.")
+	}
+
 	issues, err := loadIssues()
 	if err != nil {
 		log.Fatalf("Error loading issues: %v", err)
 	}
 
-	funcMap := template.FuncMap{"join": strings.Join}
-
-	switch format {
-	case "markdown":
-		t, err := template.New("publish").Funcs(funcMap).Parse(markdownTpl)
-		if err != nil {
-			log.Fatalf("Error parsing template: %v", err)
-		}
-		if err := t.Execute(os.Stdout, issues); err != nil {
-			log.Fatalf("Error executing template: %v", err)
+	found := false
+	for i := range issues {
+		if issues[i].ID == id {
+			for _, a := range issues[i].Assignees {
+				if a == user {
+					fmt.Printf("%s is already assigned to issue #%d.\n", user, id)
+					return
+				}
+			}
+			issues[i].Assignees = append(issues[i].Assignees, user)
+			found = true
+			break
 		}
-	case "json":
-		data, err := json.MarshalIndent(issues, "", "  ")
-		if err != nil {
-			log.Fatalf("Error exporting to JSON: %v", err)
+	}
+
+	if !found {
+		log.Fatalf("Error: Issue with ID #%d not found.", id)
+	}
+
+	if err := saveIssues(issues); err != nil {
+		log.Fatalf("Error saving updated issues: %v", err)
+	}
+
+	fmt.Printf("Assigned %s to issue #%d.\n", user, id)
+}
+
+func setMilestone(idStr, milestone string) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Fatalf("Error: Invalid ID format. Please provide a number.")
+	}
+
+	issues, err := loadIssues()
+	if err != nil {
+		log.Fatalf("Error loading issues: %v", err)
+	}
+
+	found := false
+	for i := range issues {
+		if issues[i].ID == id {
+			issues[i].Milestone = milestone
+			found = true
+			break
 		}
-		fmt.Println(string(data))
-	default:
-		log.Fatalf("Error: Unknown format '%s'. Supported formats: markdown, json.", format)
 	}
+
+	if !found {
+		log.Fatalf("Error: Issue with ID #%d not found.", id)
+	}
+
+	if err := saveIssues(issues); err != nil {
+		log.Fatalf("Error saving updated issues: %v", err)
+	}
+
+	fmt.Printf("Set milestone %q on issue #%d.\n", milestone, id)
 }
 
-// pushToGithub creates issues in a GitHub repository from local open issues.
-// It will try to use the 'gh' CLI for repository info and authentication first.
-// If 'gh' is not available, it will fall back to environment variables.
-func pushToGithub() {
-	var token, owner, repo string
-
-	// Try to get config from 'gh' CLI first.
-	ghRepoCmd := exec.Command("gh", "repo", "view", "--json", "name,owner", "--jq", ".owner.login + \"/\" + .name")
-	ghRepoOutput, err := ghRepoCmd.Output()
-	if err == nil {
-		repoParts := strings.Split(strings.TrimSpace(string(ghRepoOutput)), "/")
-		if len(repoParts) == 2 {
-			owner = repoParts[0]
-			repo = repoParts[1]
+func commentOnIssue(idStr, text string) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		log.Fatalf("Error: Invalid ID format. Please provide a number.")
+	}
+	if text == "" {
+		log.Fatal("Error: 'comment' command requires comment text.")
+	}
+
+	issues, err := loadIssues()
+	if err != nil {
+		log.Fatalf("Error loading issues: %v", err)
+	}
+
+	found := false
+	for i := range issues {
+		if issues[i].ID == id {
+			issues[i].Comments = append(issues[i].Comments, Comment{
+				Author:    commentAuthor(),
+				Text:      text,
+				CreatedAt: time.Now(),
+			})
+			found = true
+			break
 		}
 	}
 
-	ghTokenCmd := exec.Command("gh", "auth", "token")
-	ghTokenOutput, err := ghTokenCmd.Output()
-	if err == nil {
-		token = strings.TrimSpace(string(ghTokenOutput))
+	if !found {
+		log.Fatalf("Error: Issue with ID #%d not found.", id)
+	}
+
+	if err := saveIssues(issues); err != nil {
+		log.Fatalf("Error saving updated issues: %v", err)
 	}
 
-	if owner != "" && repo != "" && token != "" {
-		fmt.Printf("Detected repository '%s/%s' and using auth token from 'gh' CLI.\n", owner, repo)
-	} else {
-		fmt.Println("Could not get repository info or token from 'gh' CLI. Falling back to environment variables.")
-		token = os.Getenv("GITHUB_TOKEN")
-		owner = os.Getenv("GITHUB_OWNER")
-		repo = os.Getenv("GITHUB_REPO")
+	fmt.Printf("Added comment to issue #%d.\n", id)
+}
 
-		if token == "" || owner == "" || repo == "" {
-			log.Fatal("Error: Please install and authenticate the 'gh' CLI ('gh auth login'), or set GITHUB_TOKEN, GITHUB_OWNER, and GITHUB_REPO environment variables.")
+// commentAuthor identifies the local user for attributing new comments,
+// preferring git's configured identity and falling back to $USER.
+func commentAuthor() string {
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
 		}
 	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "unknown"
+}
 
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+// issueFrontMatter is the YAML front matter the 'edit' command shows the
+// user; it mirrors the editable subset of Issue, with the issue body as
+// the Markdown content below the closing "---".
+type issueFrontMatter struct {
+	Title     string   `yaml:"title"`
+	Status    string   `yaml:"status"`
+	Labels    []string `yaml:"labels"`
+	Assignees []string `yaml:"assignees,omitempty"`
+	Milestone string   `yaml:"milestone,omitempty"`
+	Priority  string   `yaml:"priority,omitempty"`
+}
 
-	localIssues, err := loadIssues()
+// editIssue opens $EDITOR (defaulting to vi) on a YAML front-matter file
+// for the issue, hub-style, and applies whatever the user saved back to
+// the local database.
+func editIssue(idStr string) {
+	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		log.Fatalf("Error loading local issues: %v", err)
+		log.Fatalf("Error: Invalid ID format. Please provide a number.")
 	}
 
-	fmt.Printf("Publishing open issues to %s/%s...\n", owner, repo)
-	count := 0
-	for _, issue := range localIssues {
-		if issue.Status == statusOpen {
-			gitIssue := &github.IssueRequest{
-				Title:  &issue.Title,
-				Labels: &issue.Labels,
-			}
+	issues, err := loadIssues()
+	if err != nil {
+		log.Fatalf("Error loading issues: %v", err)
+	}
 
-			_, _, err := client.Issues.Create(ctx, owner, repo, gitIssue)
-			if err != nil {
-				log.Printf("Error creating GitHub issue for local ID #%d: %v", issue.ID, err)
-				continue
-			}
-			fmt.Printf("Successfully created GitHub issue for: \"%s\"\n", issue.Title)
-			count++
+	idx := -1
+	for i := range issues {
+		if issues[i].ID == id {
+			idx = i
+			break
 		}
 	}
-	fmt.Printf("Finished. Published %d issues to GitHub.\n", count)
+	if idx == -1 {
+		log.Fatalf("Error: Issue with ID #%d not found.", id)
+	}
+
+	front := issueFrontMatter{
+		Title:     issues[idx].Title,
+		Status:    issues[idx].Status,
+		Labels:    issues[idx].Labels,
+		Assignees: issues[idx].Assignees,
+		Milestone: issues[idx].Milestone,
+		Priority:  issues[idx].Priority,
+	}
+
+	frontYAML, err := yaml.Marshal(front)
+	if err != nil {
+		log.Fatalf("Error serializing issue: %v", err)
+	}
+
+	tmpFile, err := ioutil.TempFile("", fmt.Sprintf("issue-%d-*.md", id))
+	if err != nil {
+		log.Fatalf("Error creating temp file: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	content := "---\n" + string(frontYAML) + "---\n" + issues[idx].Body
+	if err := ioutil.WriteFile(tmpFile.Name(), []byte(content), 0644); err != nil {
+		log.Fatalf("Error writing temp file: %v", err)
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command(editor, tmpFile.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Fatalf("Error running editor: %v", err)
+	}
+
+	edited, err := ioutil.ReadFile(tmpFile.Name())
+	if err != nil {
+		log.Fatalf("Error reading edited file: %v", err)
+	}
+
+	parts := strings.SplitN(string(edited), "---\n", 3)
+	if len(parts) != 3 {
+		log.Fatal("Error: edited file is missing YAML front matter delimited by '---'.")
+	}
+
+	var updatedFront issueFrontMatter
+	if err := yaml.Unmarshal([]byte(parts[1]), &updatedFront); err != nil {
+		log.Fatalf("Error parsing front matter: %v", err)
+	}
+
+	issues[idx].Title = updatedFront.Title
+	issues[idx].Status = updatedFront.Status
+	issues[idx].Labels = updatedFront.Labels
+	issues[idx].Assignees = updatedFront.Assignees
+	issues[idx].Milestone = updatedFront.Milestone
+	issues[idx].Priority = updatedFront.Priority
+	issues[idx].Body = strings.TrimPrefix(parts[2], "\n")
+
+	if err := saveIssues(issues); err != nil {
+		log.Fatalf("Error saving updated issues: %v", err)
+	}
+
+	fmt.Printf("Updated issue #%d.\n", id)
+}
+
+func publishIssues(format string) {
+	issues, err := loadIssues()
+	if err != nil {
+		log.Fatalf("Error loading issues: %v", err)
+	}
 
-	if err := saveIssues([]Issue{}); err != nil {
-		log.Fatalf("Error clearing local issues after publishing: %v", err)
+	funcMap := template.FuncMap{"join": strings.Join}
+
+	switch format {
+	case "markdown":
+		t, err := template.New("publish").Funcs(funcMap).Parse(markdownTpl)
+		if err != nil {
+			log.Fatalf("Error parsing template: %v", err)
+		}
+		if err := t.Execute(os.Stdout, issues); err != nil {
+			log.Fatalf("Error executing template: %v", err)
+		}
+	case "json":
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			log.Fatalf("Error exporting to JSON: %v", err)
+		}
+		fmt.Println(string(data))
+	default:
+		log.Fatalf("Error: Unknown format '%s'. Supported formats: markdown, json.", format)
 	}
-	fmt.Println("Successfully cleared all local issues.")
 }
 
 // --- Main Function and CLI Handling ---
 
 func printHelp() {
-	fmt.Println(`
+	helpText := `
 Issue Tracker - A simple CLI tool for managing development issues.
 
 Usage:
@@ -377,20 +560,70 @@ Commands:
   list [--label=<l>]    Lists all open issues.
                         --label: Filter issues by a specific label.
                         --all: Show closed issues as well.
+                        --format: Render each issue with a template of %
+                        placeholders (hub issue -f style) instead of the
+                        default table. Placeholders: %I id, %t title,
+                        %S state, %l colored labels, %L raw labels,
+                        %cD created date, %au author, %n newline,
+                        %sC/%Creset start/reset ANSI color. Also accepts
+                        the named formats --format=short, --format=json,
+                        and --format=tsv.
 
   close <id>            Closes an issue by its ID.
 
+  assign <id> <user>    Adds a user to an issue's assignee list.
+
+  milestone <id> <name> Sets an issue's milestone.
+
+  comment <id> "<text>" Adds a timestamped comment to an issue, attributed to
+                        the local git user.name (falling back to $USER).
+
+  edit <id>             Opens $EDITOR (or vi) on the issue as a YAML
+                        front-matter file with the body as Markdown below it.
+                        Title, status, labels, assignees, milestone, priority,
+                        and body are all applied from what you save.
+
   publish <format>      Publishes all issues in a specified format (markdown, json).
                         Example: issue-tracker publish markdown > ISSUES.md
 
-  push        Pushes all open issues to a GitHub repository and
-                        clears the local issue database.
-                        This command will automatically use the official 'gh' CLI
-                        for authentication and repository detection if installed.
-                        As a fallback, it will use GITHUB_TOKEN, GITHUB_OWNER,
-                        and GITHUB_REPO environment variables.
-
-  help                  Shows this help message.`)
+  sync [--push] [--pull] [--backend=<name>] [--concurrency=<n>]
+                        Syncs issues with a remote issue tracker. --push creates
+                        or updates the remote issue for each local issue;
+                        --pull imports remote issues into the local database,
+                        matching by remote issue number (currently only the
+                        github backend supports --pull). Passing neither flag
+                        is the same as passing both. Already-synced issues are
+                        patched in place rather than recreated, so sync is
+                        safe to run repeatedly.
+                        --backend selects the remote tracker: github (default),
+                        gitlab, or gitea. It can also be set persistently via
+                        a "backend=<name>" line in .issueify/config.
+                        Each backend prefers its platform's CLI (gh, glab, tea)
+                        for authentication and repository detection, falling
+                        back to environment variables (GITHUB_TOKEN/OWNER/REPO,
+                        GITLAB_TOKEN/PROJECT, GITEA_URL/TOKEN/OWNER/REPO).
+                        --concurrency controls how many issues --push pushes
+                        at once (default 4). Rate-limit responses are waited
+                        out and retried automatically; other failures get an
+                        exponential backoff retry. An issue that still fails
+                        keeps its local state untouched and is simply picked
+                        up again on the next sync.
+
+  push [--concurrency=<n>]
+                        Alias for 'sync --push', kept for backwards
+                        compatibility.
+
+  run-bots [--dry-run]  Applies the automation rules configured in
+                        .issueify/rules.yaml to the local issue database:
+                        freezing idle issues, auto-labeling, auto-closing
+                        stale issues, and auto-assigning milestones by
+                        label. Also updates the corresponding remote issue
+                        when the configured backend's credentials are
+                        available. --dry-run reports what would change
+                        without saving anything.
+
+  help                  Shows this help message.`
+	fmt.Println(helpText)
 }
 
 func main() {
@@ -411,30 +644,103 @@ func main() {
 	case "list":
 		filterLabel := ""
 		showAll := false
+		format := ""
 		for _, arg := range args {
-			if strings.HasPrefix(arg, "--label=") {
+			switch {
+			case strings.HasPrefix(arg, "--label="):
 				filterLabel = strings.TrimPrefix(arg, "--label=")
-			} else if arg == "--all" {
+			case arg == "--all":
 				showAll = true
+			case strings.HasPrefix(arg, "--format="):
+				format = strings.TrimPrefix(arg, "--format=")
 			}
 		}
-		listIssues(filterLabel, showAll)
+		listIssues(filterLabel, showAll, format)
 	case "close":
 		if len(args) != 1 {
 			log.Fatal("Error: 'close' command requires exactly one ID.")
 		}
 		closeIssue(args[0])
+	case "assign":
+		if len(args) != 2 {
+			log.Fatal("Error: 'assign' command requires an ID and a user.")
+		}
+		assignIssue(args[0], args[1])
+	case "milestone":
+		if len(args) != 2 {
+			log.Fatal("Error: 'milestone' command requires an ID and a milestone name.")
+		}
+		setMilestone(args[0], args[1])
+	case "comment":
+		if len(args) < 2 {
+			log.Fatal("Error: 'comment' command requires an ID and comment text.")
+		}
+		commentOnIssue(args[0], strings.Join(args[1:], " "))
+	case "edit":
+		if len(args) != 1 {
+			log.Fatal("Error: 'edit' command requires exactly one ID.")
+		}
+		editIssue(args[0])
 	case "publish":
 		if len(args) != 1 {
 			log.Fatal("Error: 'publish' command requires a format.")
 		}
 		publishIssues(args[0])
+	case "sync":
+		pull, push := false, false
+		backendName := ""
+		concurrency := defaultPushConcurrency
+		for _, arg := range args {
+			switch {
+			case arg == "--pull":
+				pull = true
+			case arg == "--push":
+				push = true
+			case strings.HasPrefix(arg, "--backend="):
+				backendName = strings.TrimPrefix(arg, "--backend=")
+			case strings.HasPrefix(arg, "--concurrency="):
+				n, err := strconv.Atoi(strings.TrimPrefix(arg, "--concurrency="))
+				if err != nil {
+					log.Fatalf("Error: Invalid --concurrency value. Please provide a number.")
+				}
+				concurrency = n
+			}
+		}
+		if !pull && !push {
+			pull, push = true, true
+		}
+		if backendName == "" {
+			configured, err := configuredBackend()
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			backendName = configured
+		}
+		syncIssues(backendName, pull, push, concurrency)
 	case "push":
-		pushToGithub()
+		concurrency := defaultPushConcurrency
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "--concurrency=") {
+				n, err := strconv.Atoi(strings.TrimPrefix(arg, "--concurrency="))
+				if err != nil {
+					log.Fatalf("Error: Invalid --concurrency value. Please provide a number.")
+				}
+				concurrency = n
+			}
+		}
+		syncIssues("", false, true, concurrency)
+	case "run-bots":
+		dryRun := false
+		for _, arg := range args {
+			if arg == "--dry-run" {
+				dryRun = true
+			}
+		}
+		runBots(dryRun)
 	case "help":
 		printHelp()
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		printHelp()
 	}
-}
\ No newline at end of file
+}
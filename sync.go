@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultPushConcurrency is how many issues pushIssues pushes at once when
+// the caller doesn't override it with --concurrency.
+const defaultPushConcurrency = 4
+
+const (
+	maxPushAttempts = 5
+	pushBackoffBase = 500 * time.Millisecond
+	pushBackoffCap  = 30 * time.Second
+)
+
+// rateLimitWaiter is implemented by backends that can recognize a
+// rate-limit error and report how long to wait before retrying, mirroring
+// the rate-limit handling in git-bug's GitHub bridge. Not every Backend
+// supports this; pushIssues falls back to plain exponential backoff for
+// those that don't.
+type rateLimitWaiter interface {
+	RateLimitDelay(err error) (time.Duration, bool)
+}
+
+// RemoteIssue is a backend-agnostic view of an issue fetched from a remote
+// tracker, used to reconcile `sync --pull` results into the local database.
+type RemoteIssue struct {
+	RemoteID  int
+	RemoteURL string
+	Title     string
+	Status    string
+	Labels    []string
+	CreatedAt time.Time
+}
+
+// remoteLister is implemented by backends that can enumerate their existing
+// remote issues, which `sync --pull` requires. Not every Backend supports
+// this yet.
+type remoteLister interface {
+	ListRemoteIssues(ctx context.Context) ([]RemoteIssue, error)
+}
+
+// commentPoster is implemented by backends that can attach a comment to a
+// remote issue. pushIssues uses it to carry over an issue's comments the
+// first time it is created remotely.
+type commentPoster interface {
+	PostComment(ctx context.Context, remoteID int, comment Comment) error
+}
+
+// syncIssues reconciles the local issue database with the named backend.
+// push creates or updates the remote counterpart of every local issue
+// without recreating ones that already carry a RemoteID; pull imports and
+// reconciles remote issues into the local database by RemoteID. Unlike the
+// original push-then-wipe behavior, no local data is discarded. concurrency
+// caps how many issues push works on at once; a value below 1 falls back to
+// defaultPushConcurrency.
+func syncIssues(backendName string, pull, push bool, concurrency int) {
+	backend, err := newBackend(backendName)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	owner, repo, err := backend.DetectRepo()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	issues, err := loadIssues()
+	if err != nil {
+		log.Fatalf("Error loading local issues: %v", err)
+	}
+
+	ctx := context.Background()
+
+	if push {
+		issues = pushIssues(ctx, backend, owner, repo, issues, concurrency)
+
+		// Save right away so a failure during the pull phase below can never
+		// discard the RemoteIDs push just assigned; otherwise the next sync
+		// would see RemoteID == 0 again and recreate every issue remotely.
+		if err := saveIssues(issues); err != nil {
+			log.Fatalf("Error saving issues: %v", err)
+		}
+	}
+	if pull {
+		lister, ok := backend.(remoteLister)
+		if !ok {
+			log.Fatalf("Error: the %q backend does not support --pull yet.", backendName)
+		}
+		pulled, err := pullIssues(ctx, backend, lister, owner, repo, issues)
+		if err != nil {
+			log.Printf("Error pulling remote issues: %v", err)
+			return
+		}
+		issues = pulled
+	}
+
+	if err := saveIssues(issues); err != nil {
+		log.Fatalf("Error saving issues: %v", err)
+	}
+}
+
+// pushIssues creates or updates the remote counterpart of every local issue
+// concurrently, using up to concurrency workers, and returns the issues
+// with their sync metadata refreshed. A failure on one issue is logged and
+// left for the next run; it never aborts the issues around it, so the
+// local database is safe to save no matter how many issues failed.
+func pushIssues(ctx context.Context, backend Backend, owner, repo string, issues []Issue, concurrency int) []Issue {
+	if concurrency < 1 {
+		concurrency = defaultPushConcurrency
+	}
+	fmt.Printf("Pushing %d issues to %s/%s with %d worker(s)...\n", len(issues), owner, repo, concurrency)
+
+	var progressMu sync.Mutex
+	done, pushed := 0, 0
+	reportProgress := func(ok bool) {
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		done++
+		if ok {
+			pushed++
+		}
+		fmt.Printf("\rPushed %d/%d issues", done, len(issues))
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, concurrency)
+
+	for i := range issues {
+		i := i
+		g.Go(func() error {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ok := pushIssue(gctx, backend, &issues[i])
+			reportProgress(ok)
+			return nil
+		})
+	}
+	g.Wait()
+	fmt.Println()
+	fmt.Printf("Finished push. Synced %d/%d issues.\n", pushed, len(issues))
+
+	return issues
+}
+
+// pushIssue creates or updates issue's remote counterpart, retrying on
+// rate-limit and transient errors, and reports whether it fully succeeded.
+func pushIssue(ctx context.Context, backend Backend, issue *Issue) bool {
+	for _, label := range issue.Labels {
+		if err := withRetry(backend, func() error { return backend.EnsureLabel(ctx, label) }); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	if issue.RemoteID == 0 {
+		var remoteID int
+		var url string
+		err := withRetry(backend, func() error {
+			var createErr error
+			remoteID, url, createErr = backend.CreateIssue(ctx, *issue)
+			return createErr
+		})
+		if err != nil {
+			log.Printf("Error creating remote issue for local ID #%d: %v", issue.ID, err)
+			return false
+		}
+		issue.RemoteID = remoteID
+		issue.RemoteURL = url
+		fmt.Printf("\nCreated remote issue #%d for: \"%s\"\n", issue.RemoteID, issue.Title)
+
+		if poster, ok := backend.(commentPoster); ok {
+			for _, comment := range issue.Comments {
+				comment := comment
+				if err := withRetry(backend, func() error { return poster.PostComment(ctx, issue.RemoteID, comment) }); err != nil {
+					log.Printf("Warning: %v", err)
+				}
+			}
+		}
+	} else {
+		if err := withRetry(backend, func() error { return backend.UpdateIssue(ctx, issue.RemoteID, *issue) }); err != nil {
+			log.Printf("Error updating remote issue #%d: %v", issue.RemoteID, err)
+			return false
+		}
+	}
+
+	if issue.Status == statusClosed {
+		if err := withRetry(backend, func() error { return backend.CloseIssue(ctx, issue.RemoteID) }); err != nil {
+			log.Printf("Error closing remote issue #%d: %v", issue.RemoteID, err)
+			return false
+		}
+	}
+
+	issue.LastSyncedAt = time.Now()
+	return true
+}
+
+// withRetry calls fn, retrying up to maxPushAttempts times. A rate-limit
+// error recognized by the backend (see rateLimitWaiter) is retried after
+// whatever delay the backend reports; any other error is retried after an
+// exponential backoff, capped at pushBackoffCap.
+func withRetry(backend Backend, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxPushAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if waiter, ok := backend.(rateLimitWaiter); ok {
+			if delay, isRateLimit := waiter.RateLimitDelay(err); isRateLimit {
+				time.Sleep(delay)
+				continue
+			}
+		}
+
+		time.Sleep(pushBackoffDelay(attempt))
+	}
+	return err
+}
+
+// pushBackoffDelay computes the exponential backoff delay for the given
+// zero-based attempt number, capped at pushBackoffCap.
+func pushBackoffDelay(attempt int) time.Duration {
+	delay := pushBackoffBase * time.Duration(1<<attempt)
+	if delay > pushBackoffCap {
+		delay = pushBackoffCap
+	}
+	return delay
+}
+
+// pullIssues fetches every remote issue, updates the local issues already
+// mapped to a RemoteID, and imports any remote issue that has no local
+// counterpart yet. It returns an error instead of aborting the process so
+// that a caller which already pushed local changes can still save them.
+func pullIssues(ctx context.Context, backend Backend, lister remoteLister, owner, repo string, issues []Issue) ([]Issue, error) {
+	var remoteIssues []RemoteIssue
+	err := withRetry(backend, func() error {
+		var listErr error
+		remoteIssues, listErr = lister.ListRemoteIssues(ctx)
+		return listErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list remote issues: %w", err)
+	}
+
+	byRemoteID := make(map[int]int, len(issues)) // RemoteID -> index into issues
+	maxID := 0
+	for i, issue := range issues {
+		if issue.RemoteID != 0 {
+			byRemoteID[issue.RemoteID] = i
+		}
+		if issue.ID > maxID {
+			maxID = issue.ID
+		}
+	}
+
+	fmt.Printf("Pulling issues from %s/%s...\n", owner, repo)
+	imported := 0
+	for _, ri := range remoteIssues {
+		if idx, ok := byRemoteID[ri.RemoteID]; ok {
+			issues[idx].Title = ri.Title
+			issues[idx].Status = ri.Status
+			issues[idx].LastSyncedAt = time.Now()
+			continue
+		}
+
+		maxID++
+		issues = append(issues, Issue{
+			ID:           maxID,
+			Title:        ri.Title,
+			Status:       ri.Status,
+			Labels:       ri.Labels,
+			CreatedAt:    ri.CreatedAt,
+			RemoteID:     ri.RemoteID,
+			RemoteURL:    ri.RemoteURL,
+			LastSyncedAt: time.Now(),
+		})
+		imported++
+	}
+	fmt.Printf("Finished pull. Imported %d new issues.\n", imported)
+
+	return issues, nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSyncBackend is a minimal Backend test double for withRetry: it fails
+// the first failCount calls made through it, then succeeds. If waitDelay is
+// non-nil, it also implements rateLimitWaiter, reporting every failure as a
+// rate limit so tests never sleep a real exponential backoff.
+type fakeSyncBackend struct {
+	fakeBackend
+	waitDelay *time.Duration
+}
+
+func (b *fakeSyncBackend) RateLimitDelay(err error) (time.Duration, bool) {
+	if b.waitDelay == nil {
+		return 0, false
+	}
+	return *b.waitDelay, true
+}
+
+func TestWithRetrySucceedsWithoutRetrying(t *testing.T) {
+	calls := 0
+	err := withRetry(&fakeSyncBackend{}, func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestWithRetryRecoversAfterRateLimitedAttempts(t *testing.T) {
+	zero := time.Duration(0)
+	backend := &fakeSyncBackend{waitDelay: &zero}
+
+	calls := 0
+	err := withRetry(backend, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("rate limited")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestWithRetryExhaustsAttemptsAndReturnsLastError(t *testing.T) {
+	zero := time.Duration(0)
+	backend := &fakeSyncBackend{waitDelay: &zero}
+
+	calls := 0
+	wantErr := errors.New("still rate limited")
+	err := withRetry(backend, func() error {
+		calls++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+	if calls != maxPushAttempts {
+		t.Errorf("fn called %d times, want %d", calls, maxPushAttempts)
+	}
+}
+
+func TestWithRetryFallsBackToBackoffWhenNotRateLimited(t *testing.T) {
+	// fakeBackend does not implement rateLimitWaiter, so withRetry must take
+	// the plain exponential-backoff branch. Succeeding on the first call
+	// keeps the test fast regardless of backoff duration.
+	calls := 0
+	err := withRetry(&fakeBackend{}, func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestPushBackoffDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, pushBackoffBase},
+		{1, pushBackoffBase * 2},
+		{2, pushBackoffBase * 4},
+		{6, pushBackoffCap}, // would be 32x base uncapped, well past pushBackoffCap
+	}
+
+	for _, tt := range tests {
+		if got := pushBackoffDelay(tt.attempt); got != tt.want {
+			t.Errorf("pushBackoffDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+// fakePullLister implements remoteLister (and rateLimitWaiter, reporting a
+// zero delay) for pullIssues tests, so a failing ListRemoteIssues doesn't
+// make withRetry sleep through a real exponential backoff.
+type fakePullLister struct {
+	fakeBackend
+	remoteIssues []RemoteIssue
+	err          error
+}
+
+func (f *fakePullLister) ListRemoteIssues(ctx context.Context) ([]RemoteIssue, error) {
+	return f.remoteIssues, f.err
+}
+
+func (f *fakePullLister) RateLimitDelay(err error) (time.Duration, bool) {
+	return 0, true
+}
+
+func TestPullIssuesMergesAndImports(t *testing.T) {
+	lister := &fakePullLister{remoteIssues: []RemoteIssue{
+		{RemoteID: 1, Title: "updated title", Status: statusClosed},
+		{RemoteID: 2, Title: "new from remote", Status: statusOpen},
+	}}
+	local := []Issue{{ID: 1, Title: "old title", Status: statusOpen, RemoteID: 1}}
+
+	got, err := pullIssues(context.Background(), lister, lister, "owner", "repo", local)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d issues, want 2", len(got))
+	}
+	if got[0].Title != "updated title" || got[0].Status != statusClosed {
+		t.Errorf("existing issue not merged: %+v", got[0])
+	}
+	if got[1].RemoteID != 2 || got[1].Title != "new from remote" {
+		t.Errorf("new remote issue not imported: %+v", got[1])
+	}
+}
+
+func TestPullIssuesReturnsErrorInsteadOfFataling(t *testing.T) {
+	lister := &fakePullLister{err: errors.New("network blip")}
+
+	_, err := pullIssues(context.Background(), lister, lister, "owner", "repo", nil)
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}